@@ -0,0 +1,122 @@
+package remote_control
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthConfigDisabledAllowsEverything(t *testing.T) {
+	var a AuthConfig
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if !a.authorize(r, ScopePlayerControl) {
+		t.Error("zero-value AuthConfig should authorize every scope")
+	}
+	if !a.authorizeToken("nonexistent-token", ScopePlayerControl) {
+		t.Error("zero-value AuthConfig should authorize every token")
+	}
+}
+
+func TestAuthConfigBearerToken(t *testing.T) {
+	a := AuthConfig{
+		BearerTokens: map[string]map[Scope]bool{
+			"full":       {ScopePlayerControl: true, ScopeModeWrite: true, ScopeStatusRead: true},
+			"status-only": {ScopeStatusRead: true},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		scope Scope
+		want  bool
+	}{
+		{"full token has player control", "full", ScopePlayerControl, true},
+		{"full token has mode write", "full", ScopeModeWrite, true},
+		{"status-only token lacks player control", "status-only", ScopePlayerControl, false},
+		{"status-only token has status read", "status-only", ScopeStatusRead, true},
+		{"unknown token is rejected", "unknown", ScopeStatusRead, false},
+		{"empty token is rejected", "", ScopeStatusRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.authorizeToken(tt.token, tt.scope); got != tt.want {
+				t.Errorf("authorizeToken(%q, %q) = %v, want %v", tt.token, tt.scope, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthConfigBasicAuth(t *testing.T) {
+	a := AuthConfig{BasicUser: "admin", BasicPassword: "hunter2"}
+
+	if !a.authorizeBasic("admin", "hunter2") {
+		t.Error("matching Basic credentials should authorize")
+	}
+	if a.authorizeBasic("admin", "wrong") {
+		t.Error("wrong password should not authorize")
+	}
+	if a.authorizeBasic("someone-else", "hunter2") {
+		t.Error("wrong user should not authorize")
+	}
+
+	var noUser AuthConfig
+	if noUser.authorizeBasic("", "") {
+		t.Error("AuthConfig with no BasicUser configured should never authorize")
+	}
+}
+
+func TestAuthConfigAuthorizeFromRequest(t *testing.T) {
+	a := AuthConfig{
+		BearerTokens:  map[string]map[Scope]bool{"tok": {ScopePlayerControl: true}},
+		BasicUser:     "admin",
+		BasicPassword: "hunter2",
+	}
+
+	bearerReq := httptest.NewRequest(http.MethodGet, "/", nil)
+	bearerReq.Header.Set("Authorization", "Bearer tok")
+	if !a.authorize(bearerReq, ScopePlayerControl) {
+		t.Error("valid bearer token should authorize via authorize()")
+	}
+
+	basicReq := httptest.NewRequest(http.MethodGet, "/", nil)
+	basicReq.SetBasicAuth("admin", "hunter2")
+	if !a.authorize(basicReq, ScopePlayerControl) {
+		t.Error("valid Basic auth should authorize via authorize()")
+	}
+
+	noCredsReq := httptest.NewRequest(http.MethodGet, "/", nil)
+	if a.authorize(noCredsReq, ScopePlayerControl) {
+		t.Error("request with no credentials should not authorize when auth is enabled")
+	}
+}
+
+func TestAuthConfigCheckOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		origin  string
+		want    bool
+	}{
+		{"empty allowlist permits any origin", nil, "https://evil.example", true},
+		{"no Origin header is always permitted", []string{"https://good.example"}, "", true},
+		{"listed origin is permitted", []string{"https://good.example"}, "https://good.example", true},
+		{"unlisted origin is rejected", []string{"https://good.example"}, "https://evil.example", false},
+		{"wildcard permits any origin", []string{"*"}, "https://evil.example", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := AuthConfig{CORSAllowedOrigins: tt.allowed}
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if got := a.checkOrigin(r); got != tt.want {
+				t.Errorf("checkOrigin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package remote_control
+
+import "sync"
+
+// Event types fanned out by Broadcaster. They double as SSE `event:` names
+// and as the `type` discriminator in WebSocket status pushes.
+const (
+	EventStatus      = "status"
+	EventSongChange  = "song_change"
+	EventLyric       = "lyric"
+	EventModeChange  = "mode_change"
+	EventQueueChange = "queue_change"
+	EventError       = "error"
+)
+
+// eventBufferSize bounds how many past events Broadcaster retains for
+// Last-Event-ID resume. Older events are evicted first.
+const eventBufferSize = 256
+
+// Event is a single typed status update, identified by a monotonically
+// increasing ID so clients can resume a dropped connection without
+// missing or duplicating updates.
+type Event struct {
+	ID     uint64
+	Type   string
+	Status PlayerStatus
+}
+
+// Broadcaster fans typed status events out to every subscriber, regardless
+// of which transport (HTTP SSE, WebSocket, gRPC, Unix socket) the
+// subscriber is attached to. Controllers share one Broadcaster so a state
+// change reaches all enabled transports atomically. It also retains a
+// ring buffer of recent events so SSE clients can resume via
+// Last-Event-ID after a dropped connection.
+type Broadcaster struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+	nextID      uint64
+	buffer      []Event
+	last        *PlayerStatus
+}
+
+// NewBroadcaster creates an empty Broadcaster.
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber and returns its channel. Callers
+// must pass the channel to Unsubscribe once they stop reading from it.
+func (b *Broadcaster) Subscribe() chan Event {
+	ch := make(chan Event, 8)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+// Unsubscribe removes and closes a subscriber channel returned by Subscribe.
+func (b *Broadcaster) Unsubscribe(ch chan Event) {
+	b.mu.Lock()
+	delete(b.subscribers, ch)
+	b.mu.Unlock()
+	close(ch)
+}
+
+// SubscriberCount returns the number of currently active subscribers,
+// across every transport sharing this Broadcaster.
+func (b *Broadcaster) SubscriberCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// Publish assigns status the next event ID, retains it in the ring buffer,
+// and pushes it to every current subscriber. Slow subscribers are skipped
+// rather than blocking the publisher.
+//
+// eventType is normally EventStatus, the generic "something changed"
+// marker; Publish then classifies the update by diffing it against the
+// previously published status to pick a more specific type (EventSongChange,
+// EventLyric, EventModeChange, EventQueueChange), so that ?events= filtering
+// on the SSE transport actually narrows the stream. Callers that
+// already know a more specific type that can't be derived from PlayerStatus
+// alone, such as EventError, can pass it directly to bypass classification.
+func (b *Broadcaster) Publish(eventType string, status PlayerStatus) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if eventType == EventStatus {
+		eventType = b.classify(status)
+	}
+	b.last = &status
+
+	b.nextID++
+	event := Event{ID: b.nextID, Type: eventType, Status: status}
+
+	b.buffer = append(b.buffer, event)
+	if len(b.buffer) > eventBufferSize {
+		b.buffer = b.buffer[len(b.buffer)-eventBufferSize:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber channel is full, skip.
+		}
+	}
+}
+
+// classify picks the most specific event type for status by diffing it
+// against the last status Publish saw, preferring the first difference
+// found in this order: song, lyric, play mode, queue. A first-ever status
+// (no prior status recorded) or a status identical to the last one is
+// reported as the generic EventStatus.
+func (b *Broadcaster) classify(status PlayerStatus) string {
+	prev := b.last
+	if prev == nil {
+		return EventStatus
+	}
+	switch {
+	case prev.SongTitle != status.SongTitle || prev.Artist != status.Artist:
+		return EventSongChange
+	case prev.Lyric != status.Lyric:
+		return EventLyric
+	case prev.PlayMode != status.PlayMode:
+		return EventModeChange
+	case prev.QueueLength != status.QueueLength || prev.QueueIndex != status.QueueIndex:
+		return EventQueueChange
+	default:
+		return EventStatus
+	}
+}
+
+// EventsSince returns the buffered events with ID greater than lastID, in
+// order. If lastID is older than everything still buffered, every
+// buffered event is returned; callers that need to detect gaps should
+// compare the first returned ID against lastID+1.
+func (b *Broadcaster) EventsSince(lastID uint64) []Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := make([]Event, 0, len(b.buffer))
+	for _, event := range b.buffer {
+		if event.ID > lastID {
+			out = append(out, event)
+		}
+	}
+	return out
+}
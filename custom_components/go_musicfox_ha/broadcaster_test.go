@@ -0,0 +1,146 @@
+package remote_control
+
+import "testing"
+
+func TestBroadcasterClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		prev *PlayerStatus
+		next PlayerStatus
+		want string
+	}{
+		{
+			name: "first status has no prior status to diff against",
+			prev: nil,
+			next: PlayerStatus{SongTitle: "A"},
+			want: EventStatus,
+		},
+		{
+			name: "identical status",
+			prev: &PlayerStatus{SongTitle: "A", Artist: "X"},
+			next: PlayerStatus{SongTitle: "A", Artist: "X"},
+			want: EventStatus,
+		},
+		{
+			name: "song title changed",
+			prev: &PlayerStatus{SongTitle: "A"},
+			next: PlayerStatus{SongTitle: "B"},
+			want: EventSongChange,
+		},
+		{
+			name: "artist changed",
+			prev: &PlayerStatus{SongTitle: "A", Artist: "X"},
+			next: PlayerStatus{SongTitle: "A", Artist: "Y"},
+			want: EventSongChange,
+		},
+		{
+			name: "lyric changed",
+			prev: &PlayerStatus{SongTitle: "A", Lyric: "la la"},
+			next: PlayerStatus{SongTitle: "A", Lyric: "di di"},
+			want: EventLyric,
+		},
+		{
+			name: "play mode changed",
+			prev: &PlayerStatus{SongTitle: "A", PlayMode: 1},
+			next: PlayerStatus{SongTitle: "A", PlayMode: 2},
+			want: EventModeChange,
+		},
+		{
+			name: "queue length changed",
+			prev: &PlayerStatus{SongTitle: "A", QueueLength: 3},
+			next: PlayerStatus{SongTitle: "A", QueueLength: 4},
+			want: EventQueueChange,
+		},
+		{
+			name: "queue index changed",
+			prev: &PlayerStatus{SongTitle: "A", QueueIndex: 0},
+			next: PlayerStatus{SongTitle: "A", QueueIndex: 1},
+			want: EventQueueChange,
+		},
+		{
+			name: "song change takes priority over a simultaneous lyric change",
+			prev: &PlayerStatus{SongTitle: "A", Lyric: "la la"},
+			next: PlayerStatus{SongTitle: "B", Lyric: "di di"},
+			want: EventSongChange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Broadcaster{last: tt.prev}
+			if got := b.classify(tt.next); got != tt.want {
+				t.Errorf("classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBroadcasterPublishClassifiesStatusEvents(t *testing.T) {
+	b := NewBroadcaster()
+	sub := b.Subscribe()
+	defer b.Unsubscribe(sub)
+
+	b.Publish(EventStatus, PlayerStatus{SongTitle: "A"})
+	if got := (<-sub).Type; got != EventStatus {
+		t.Fatalf("first publish: got type %q, want %q", got, EventStatus)
+	}
+
+	b.Publish(EventStatus, PlayerStatus{SongTitle: "B"})
+	if got := (<-sub).Type; got != EventSongChange {
+		t.Fatalf("song change publish: got type %q, want %q", got, EventSongChange)
+	}
+
+	// An explicit type bypasses classification entirely.
+	b.Publish(EventError, PlayerStatus{SongTitle: "B"})
+	if got := (<-sub).Type; got != EventError {
+		t.Fatalf("explicit type publish: got type %q, want %q", got, EventError)
+	}
+}
+
+func TestBroadcasterEventsSince(t *testing.T) {
+	b := NewBroadcaster()
+
+	for i := 0; i < 5; i++ {
+		b.Publish(EventError, PlayerStatus{QueueIndex: i})
+	}
+
+	events := b.EventsSince(0)
+	if len(events) != 5 {
+		t.Fatalf("EventsSince(0): got %d events, want 5", len(events))
+	}
+	for i, event := range events {
+		if event.ID != uint64(i+1) {
+			t.Errorf("EventsSince(0)[%d].ID = %d, want %d", i, event.ID, i+1)
+		}
+	}
+
+	events = b.EventsSince(3)
+	if len(events) != 2 {
+		t.Fatalf("EventsSince(3): got %d events, want 2", len(events))
+	}
+	if events[0].ID != 4 {
+		t.Errorf("EventsSince(3)[0].ID = %d, want 4", events[0].ID)
+	}
+
+	if events := b.EventsSince(5); len(events) != 0 {
+		t.Errorf("EventsSince(5): got %d events, want 0", len(events))
+	}
+}
+
+func TestBroadcasterEventsSinceEvictsOldestPastBufferSize(t *testing.T) {
+	b := NewBroadcaster()
+
+	total := eventBufferSize + 10
+	for i := 0; i < total; i++ {
+		b.Publish(EventError, PlayerStatus{QueueIndex: i})
+	}
+
+	events := b.EventsSince(0)
+	if len(events) != eventBufferSize {
+		t.Fatalf("EventsSince(0): got %d events, want %d", len(events), eventBufferSize)
+	}
+	wantFirstID := uint64(total-eventBufferSize) + 1
+	if events[0].ID != wantFirstID {
+		t.Errorf("EventsSince(0)[0].ID = %d, want %d (oldest evicted)", events[0].ID, wantFirstID)
+	}
+}
@@ -0,0 +1,104 @@
+package remote_control
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-musicfox/go-musicfox/internal/remote_control/discovery"
+)
+
+// Controller is the common lifecycle shared by every remote-control
+// transport (HTTP, gRPC, Unix socket, ...). Each implementation wraps the
+// same PlayerController and Broadcaster so a single state change fans out
+// to every enabled transport atomically.
+type Controller interface {
+	// Run starts serving on the transport's listener. It blocks until the
+	// listener is closed or an unrecoverable error occurs.
+	Run() error
+
+	// Broadcast pushes a typed status event (see EventStatus and its
+	// siblings) to every subscriber of the shared Broadcaster, whichever
+	// transport it is connected to.
+	Broadcast(eventType string, status PlayerStatus)
+
+	// Shutdown stops accepting new connections and closes existing ones,
+	// respecting ctx's deadline.
+	Shutdown(ctx context.Context) error
+}
+
+// HTTPConfig configures the HTTP/WebSocket/SSE transport. A nil *HTTPConfig
+// passed to Config leaves the transport disabled.
+type HTTPConfig struct {
+	// Port is the TCP port the HTTP controller listens on.
+	Port int
+	// Auth configures authentication, TLS, and CORS for the transport.
+	Auth AuthConfig
+	// Discovery configures mDNS/DNS-SD advertisement of this transport.
+	Discovery discovery.Config
+}
+
+// GRPCConfig configures the gRPC transport. A nil *GRPCConfig passed to
+// Config leaves the transport disabled.
+type GRPCConfig struct {
+	// Port is the TCP port the gRPC controller listens on.
+	Port int
+	// Auth configures the bearer-token check every RPC is subject to.
+	Auth AuthConfig
+}
+
+// UnixSocketConfig configures the Unix domain socket transport. A nil
+// *UnixSocketConfig passed to Config leaves the transport disabled.
+type UnixSocketConfig struct {
+	// SocketPath is the filesystem path the controller listens on.
+	SocketPath string
+}
+
+// Config selects which remote-control transports StartAll brings up. Each
+// field is independently optional, mirroring discovery.Config's
+// Enabled-gated opt-in: an operator can run any subset of HTTP, gRPC, and
+// Unix socket concurrently, all sharing one PlayerController and
+// Broadcaster.
+type Config struct {
+	HTTP       *HTTPConfig
+	GRPC       *GRPCConfig
+	UnixSocket *UnixSocketConfig
+}
+
+// StartAll builds a Controller for every transport enabled in cfg and runs
+// each one on its own goroutine, all sharing playerController and a single
+// Broadcaster so a status change fans out to every enabled transport
+// atomically. It returns the running controllers (for Broadcast) and a
+// shutdown func that stops them all, respecting its ctx argument's deadline.
+func StartAll(playerController PlayerController, cfg Config) ([]Controller, func(context.Context) error) {
+	broadcaster := NewBroadcaster()
+	var controllers []Controller
+
+	if cfg.HTTP != nil {
+		controllers = append(controllers, NewHTTPController(playerController, broadcaster, cfg.HTTP.Port, cfg.HTTP.Auth, cfg.HTTP.Discovery))
+	}
+	if cfg.GRPC != nil {
+		controllers = append(controllers, NewGRPCController(playerController, broadcaster, cfg.GRPC.Port, cfg.GRPC.Auth))
+	}
+	if cfg.UnixSocket != nil {
+		controllers = append(controllers, NewUnixSocketController(playerController, broadcaster, cfg.UnixSocket.SocketPath))
+	}
+
+	for _, controller := range controllers {
+		go func(controller Controller) {
+			if err := controller.Run(); err != nil {
+				slog.Error("remote control transport stopped", slog.Any("err", err))
+			}
+		}(controller)
+	}
+
+	shutdown := func(ctx context.Context) error {
+		var firstErr error
+		for _, controller := range controllers {
+			if err := controller.Shutdown(ctx); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+	return controllers, shutdown
+}
@@ -0,0 +1,51 @@
+package remote_control
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-musicfox/go-musicfox/internal/types"
+)
+
+// fakePlayerController is a minimal, concurrency-safe PlayerController used
+// across this package's tests; it records nothing beyond the current
+// status and tolerates every command.
+type fakePlayerController struct {
+	mu     sync.Mutex
+	status PlayerStatus
+}
+
+func (f *fakePlayerController) SetPlayMode(types.Mode) error { return nil }
+func (f *fakePlayerController) Play()                        {}
+func (f *fakePlayerController) Pause()                       {}
+func (f *fakePlayerController) Next()                        {}
+func (f *fakePlayerController) Previous()                    {}
+func (f *fakePlayerController) NextPlayMode()                {}
+func (f *fakePlayerController) ActivateIntelligentMode() error { return nil }
+func (f *fakePlayerController) Rerender()                      {}
+func (f *fakePlayerController) SetVolume(int) error              { return nil }
+func (f *fakePlayerController) Seek(time.Duration) error         { return nil }
+func (f *fakePlayerController) SeekRelative(time.Duration) error { return nil }
+func (f *fakePlayerController) Enqueue(string) error             { return nil }
+func (f *fakePlayerController) Dequeue(int) error                { return nil }
+func (f *fakePlayerController) ClearQueue()                      {}
+func (f *fakePlayerController) MoveInQueue(int, int) error       { return nil }
+func (f *fakePlayerController) PlayIndex(int) error              { return nil }
+func (f *fakePlayerController) LikeSong(string) error             { return nil }
+func (f *fakePlayerController) UnlikeSong(string) error           { return nil }
+func (f *fakePlayerController) AddToPlaylist(string, string) error { return nil }
+func (f *fakePlayerController) IsAlive() bool         { return true }
+func (f *fakePlayerController) Uptime() time.Duration { return time.Minute }
+func (f *fakePlayerController) SongsPlayed() uint64   { return 0 }
+
+func (f *fakePlayerController) GetStatus() PlayerStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.status
+}
+
+func (f *fakePlayerController) setStatus(status PlayerStatus) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = status
+}
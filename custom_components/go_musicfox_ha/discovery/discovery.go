@@ -0,0 +1,79 @@
+// Package discovery advertises a running musicfox instance over mDNS/DNS-SD
+// (Bonjour) so phone apps, Home Assistant, and companion controllers can
+// find it on the LAN without a hardcoded IP or port.
+package discovery
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/grandcat/zeroconf"
+)
+
+// serviceType is the Bonjour service type musicfox advertises itself under.
+const serviceType = "_musicfox._tcp"
+
+// apiVersion is the remote control API version advertised in the service's
+// TXT record.
+const apiVersion = "v1"
+
+// Config configures Bonjour/DNS-SD advertisement of the remote control API.
+type Config struct {
+	// Enabled registers the service when true. Defaults to false so
+	// privacy-sensitive users keep the previous hardcoded-IP-only
+	// behavior unless they opt in.
+	Enabled bool
+	// Port is the remote control API's listening port, advertised in the
+	// service record.
+	Port int
+	// InstanceID is a stable identifier for this musicfox instance,
+	// advertised in the TXT record and used as the mDNS instance name. A
+	// random UUID is generated if left empty.
+	InstanceID string
+}
+
+// Service is a registered mDNS advertisement. A nil *Service is valid and
+// Shutdown on it is a no-op, matching a disabled Config.
+type Service struct {
+	server *zeroconf.Server
+}
+
+// Register advertises cfg on the LAN. It returns a nil *Service, nil error
+// when cfg.Enabled is false.
+func Register(cfg Config) (*Service, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	instanceID := cfg.InstanceID
+	if instanceID == "" {
+		instanceID = uuid.NewString()
+	}
+
+	server, err := zeroconf.Register(
+		instanceID,
+		serviceType,
+		"local.",
+		cfg.Port,
+		[]string{
+			fmt.Sprintf("api_version=%s", apiVersion),
+			fmt.Sprintf("instance_id=%s", instanceID),
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: register mDNS service failed: %w", err)
+	}
+
+	slog.Info("discovery service registered", slog.String("type", serviceType), slog.Int("port", cfg.Port), slog.String("instance_id", instanceID))
+	return &Service{server: server}, nil
+}
+
+// Shutdown unregisters the mDNS service.
+func (s *Service) Shutdown() {
+	if s == nil || s.server == nil {
+		return
+	}
+	s.server.Shutdown()
+}
@@ -0,0 +1,245 @@
+package remote_control
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// jsonCodec marshals gRPC messages as JSON instead of protobuf, so
+// PlayerService can exchange the same PlayerStatus/command types used by
+// the HTTP and WebSocket transports without a protoc build step.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                               { return "json" }
+
+// CommandRequest is the unary request accepted by PlayerService.SendCommand.
+type CommandRequest struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// CommandReply is the unary response returned by PlayerService.SendCommand.
+type CommandReply struct {
+	Ok      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+}
+
+// SubscribeStatusRequest is the (currently empty) request that opens a
+// PlayerService.SubscribeStatus stream.
+type SubscribeStatusRequest struct{}
+
+// PlayerServiceServer is the gRPC-native analogue of PlayerController,
+// exposed so gRPC clients can issue commands and subscribe to status
+// pushes over the same shared Broadcaster as the other transports.
+type PlayerServiceServer interface {
+	SendCommand(context.Context, *CommandRequest) (*CommandReply, error)
+	SubscribeStatus(*SubscribeStatusRequest, PlayerService_SubscribeStatusServer) error
+}
+
+// PlayerService_SubscribeStatusServer streams PlayerStatus updates to a
+// single gRPC client.
+type PlayerService_SubscribeStatusServer interface {
+	Send(*PlayerStatus) error
+	grpc.ServerStream
+}
+
+type playerServiceSubscribeStatusServer struct {
+	grpc.ServerStream
+}
+
+func (s *playerServiceSubscribeStatusServer) Send(status *PlayerStatus) error {
+	return s.ServerStream.SendMsg(status)
+}
+
+var playerServiceDesc = grpc.ServiceDesc{
+	ServiceName: "musicfox.remotecontrol.v1.PlayerService",
+	HandlerType: (*PlayerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendCommand",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := new(CommandRequest)
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(PlayerServiceServer).SendCommand(ctx, req)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeStatus",
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				req := new(SubscribeStatusRequest)
+				if err := stream.RecvMsg(req); err != nil {
+					return err
+				}
+				return srv.(PlayerServiceServer).SubscribeStatus(req, &playerServiceSubscribeStatusServer{stream})
+			},
+		},
+	},
+}
+
+// GRPCController is a Controller implementation exposing PlayerService over
+// gRPC, sharing a PlayerController and Broadcaster with the other
+// transports.
+type GRPCController struct {
+	playerController  PlayerController
+	broadcaster       *Broadcaster
+	port              int
+	auth              AuthConfig
+	server            *grpc.Server
+	shutdownRequested bool
+	mu                sync.Mutex
+}
+
+// NewGRPCController creates a GRPCController serving on port. As with
+// HTTPController, a zero-value AuthConfig leaves every RPC open. Otherwise
+// every RPC must present a bearer token in its "authorization" metadata;
+// unlike HTTPController, Basic credentials are not accepted, and the TLS
+// fields of AuthConfig are not used.
+func NewGRPCController(playerController PlayerController, broadcaster *Broadcaster, port int, auth AuthConfig) *GRPCController {
+	return &GRPCController{
+		playerController: playerController,
+		broadcaster:      broadcaster,
+		port:             port,
+		auth:             auth,
+	}
+}
+
+// tokenFromContext extracts the bearer token from a gRPC request's
+// "authorization" metadata, gRPC's analogue of the HTTP Authorization
+// header. Returns "" if the request carries no such metadata.
+func tokenFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	token, _ := strings.CutPrefix(values[0], "Bearer ")
+	return token
+}
+
+// Run starts the gRPC server and blocks until it is shut down. If Shutdown
+// is called before Run has finished setting up its listener, Run notices
+// and exits without serving, rather than leaving an orphaned server
+// running after the caller believes shutdown completed.
+func (c *GRPCController) Run() error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", c.port))
+	if err != nil {
+		return fmt.Errorf("grpc controller listen failed: %w", err)
+	}
+
+	server := grpc.NewServer(grpc.ForceServerCodec(jsonCodec{}))
+	server.RegisterService(&playerServiceDesc, (PlayerServiceServer)(c))
+
+	c.mu.Lock()
+	if c.shutdownRequested {
+		c.mu.Unlock()
+		_ = lis.Close()
+		return nil
+	}
+	c.server = server
+	c.mu.Unlock()
+
+	slog.Info("gRPC controller is running on", slog.Int("port", c.port))
+	if err := server.Serve(lis); err != nil {
+		slog.Error("grpc controller serve failed", slog.Any("err", err))
+		return err
+	}
+	return nil
+}
+
+// Shutdown gracefully stops the gRPC server.
+func (c *GRPCController) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	c.shutdownRequested = true
+	server := c.server
+	c.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
+	}
+}
+
+// Broadcast publishes a typed status event to every subscriber of the
+// shared Broadcaster.
+func (c *GRPCController) Broadcast(eventType string, status PlayerStatus) {
+	c.broadcaster.Publish(eventType, status)
+}
+
+// SendCommand dispatches a gRPC command request through the same command
+// handling used by the HTTP and WebSocket transports, subject to the same
+// per-command scope check as those transports.
+func (c *GRPCController) SendCommand(ctx context.Context, req *CommandRequest) (*CommandReply, error) {
+	if !c.auth.authorizeToken(tokenFromContext(ctx), scopeForCommand(req.Command)) {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+
+	message, code := dispatchCommand(c.playerController, command{Command: req.Command, Args: req.Args})
+	if code != http.StatusOK {
+		return nil, status.Error(codes.InvalidArgument, message)
+	}
+	return &CommandReply{Ok: true}, nil
+}
+
+// SubscribeStatus streams player status updates to a gRPC client until it
+// disconnects.
+func (c *GRPCController) SubscribeStatus(_ *SubscribeStatusRequest, stream PlayerService_SubscribeStatusServer) error {
+	if !c.auth.authorizeToken(tokenFromContext(stream.Context()), ScopeStatusRead) {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+
+	sub := c.broadcaster.Subscribe()
+	defer c.broadcaster.Unsubscribe(sub)
+
+	initial := c.playerController.GetStatus()
+	if err := stream.Send(&initial); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event, ok := <-sub:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(&event.Status); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
@@ -1,14 +1,19 @@
 package remote_control
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/go-musicfox/go-musicfox/internal/remote_control/discovery"
 	"github.com/go-musicfox/go-musicfox/internal/types"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // PlayerStatus contains the current player status
@@ -21,6 +26,10 @@ type PlayerStatus struct {
 	PlaybackPlayed time.Duration `json:"playback_played"`
 	Lyric          string        `json:"lyric"`
 	IsLoggedIn     bool          `json:"is_logged_in"`
+	Volume         int           `json:"volume"`
+	QueueLength    int           `json:"queue_length"`
+	QueueIndex     int           `json:"queue_index"`
+	Liked          bool          `json:"liked"`
 }
 
 // PlayerController player controller interface
@@ -34,73 +43,184 @@ type PlayerController interface {
 	ActivateIntelligentMode() error
 	GetStatus() PlayerStatus
 	Rerender()
+
+	// SetVolume sets the output volume, 0-100.
+	SetVolume(volume int) error
+	// Seek moves playback to an absolute position in the current song.
+	Seek(position time.Duration) error
+	// SeekRelative moves playback by offset relative to the current
+	// position; offset may be negative to seek backwards.
+	SeekRelative(offset time.Duration) error
+
+	// Enqueue appends songID to the play queue.
+	Enqueue(songID string) error
+	// Dequeue removes the song at index from the play queue.
+	Dequeue(index int) error
+	// ClearQueue empties the play queue.
+	ClearQueue()
+	// MoveInQueue moves the song at from to the to position.
+	MoveInQueue(from, to int) error
+	// PlayIndex jumps to and plays the song at index in the queue.
+	PlayIndex(index int) error
+
+	// LikeSong likes songID, or the currently playing song if songID is
+	// empty.
+	LikeSong(songID string) error
+	// UnlikeSong removes songID, or the currently playing song if songID
+	// is empty, from the user's liked songs.
+	UnlikeSong(songID string) error
+	// AddToPlaylist adds songID, or the currently playing song if songID
+	// is empty, to playlistID.
+	AddToPlaylist(playlistID, songID string) error
+
+	// IsAlive reports whether the player's main goroutine is still
+	// running, for health checks.
+	IsAlive() bool
+	// Uptime returns how long the player has been running.
+	Uptime() time.Duration
+	// SongsPlayed returns the number of songs played this session.
+	SongsPlayed() uint64
 }
 
-// HTTPController http controller
+// HTTPController is the HTTP/SSE/WebSocket Controller implementation. It is
+// one of several transports that can share a single PlayerController and
+// Broadcaster; see Controller.
 type HTTPController struct {
 	playerController PlayerController
-	sseClients       map[chan string]struct{}
+	broadcaster      *Broadcaster
+	port             int
+	auth             AuthConfig
+	discoveryCfg     discovery.Config
+	discoverySvc     *discovery.Service
+	metrics          *controllerMetrics
+	server           *http.Server
 	mu               sync.Mutex
 }
 
-// NewHTTPController new http controller
-func NewHTTPController(playerController PlayerController) *HTTPController {
+// NewHTTPController creates an HTTPController serving on port, fanning
+// status updates out through broadcaster. auth configures bearer/Basic
+// authentication, TLS, and CORS; its zero value disables all three.
+// discoveryCfg configures LAN auto-discovery via mDNS/DNS-SD; its zero
+// value leaves discovery disabled.
+func NewHTTPController(playerController PlayerController, broadcaster *Broadcaster, port int, auth AuthConfig, discoveryCfg discovery.Config) *HTTPController {
 	return &HTTPController{
 		playerController: playerController,
-		sseClients:       make(map[chan string]struct{}),
+		broadcaster:      broadcaster,
+		port:             port,
+		auth:             auth,
+		discoveryCfg:     discoveryCfg,
+		metrics:          newControllerMetrics(playerController, broadcaster),
 	}
 }
 
-// Run run http server
-func (c *HTTPController) Run(port int) {
-	slog.Info("HTTP controller is running on", slog.Int("port", port))
-	http.HandleFunc("/api/v1/command", c.commandHandler)
-	http.HandleFunc("/api/v1/status", c.statusHandler)
-	http.HandleFunc("/api/v1/events", c.sseHandler)
-	go func() {
-		if err := http.ListenAndServe(fmt.Sprintf(":%d", port), nil); err != nil {
-			slog.Error("http controller listen and serve failed", slog.Any("err", err))
-		}
-	}()
+// Run starts the HTTP server and blocks until it is shut down. It serves
+// over TLS when auth's cert/key paths are set, otherwise plain HTTP.
+func (c *HTTPController) Run() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/command", c.commandHandler)
+	mux.HandleFunc("/api/v1/status", c.requireScope(ScopeStatusRead, c.statusHandler))
+	mux.HandleFunc("/api/v1/events", c.requireScope(ScopeStatusRead, c.sseHandler))
+	mux.HandleFunc("/api/v1/ws", c.requireScope(ScopeStatusRead, c.wsHandler))
+	mux.Handle("/metrics", promhttp.HandlerFor(c.metrics.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", c.healthzHandler)
+	mux.HandleFunc("/readyz", c.readyzHandler)
+
+	c.mu.Lock()
+	c.server = &http.Server{
+		Addr:    fmt.Sprintf(":%d", c.port),
+		Handler: mux,
+	}
+	server := c.server
+	c.mu.Unlock()
+
+	c.discoveryCfg.Port = c.port
+	if svc, err := discovery.Register(c.discoveryCfg); err != nil {
+		slog.Error("discovery registration failed", slog.Any("err", err))
+	} else {
+		c.mu.Lock()
+		c.discoverySvc = svc
+		c.mu.Unlock()
+	}
+
+	slog.Info("HTTP controller is running on", slog.Int("port", c.port))
+	var err error
+	if c.auth.tlsEnabled() {
+		err = server.ListenAndServeTLS(c.auth.TLSCertFile, c.auth.TLSKeyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		slog.Error("http controller listen and serve failed", slog.Any("err", err))
+		return err
+	}
+	return nil
 }
 
-// BroadcastStatus broadcasts the current player status to all SSE clients.
-func (c *HTTPController) BroadcastStatus(status PlayerStatus) {
+// Shutdown gracefully stops the HTTP server and unregisters the discovery
+// service, if any. WebSocket connections are not closed by it, and an open
+// SSE stream keeps it waiting until ctx is done, when it returns ctx's
+// error with that stream still open.
+func (c *HTTPController) Shutdown(ctx context.Context) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	server := c.server
+	discoverySvc := c.discoverySvc
+	c.mu.Unlock()
 
-	jsonData, err := json.Marshal(status)
-	if err != nil {
-		slog.Error("Failed to marshal status for SSE broadcast", slog.Any("err", err))
-		return
+	discoverySvc.Shutdown()
+
+	if server == nil {
+		return nil
 	}
+	return server.Shutdown(ctx)
+}
 
-	for clientChan := range c.sseClients {
-		select {
-		case clientChan <- string(jsonData):
-		default:
-			// Client channel is full, skip.
-		}
+// Broadcast publishes a typed status event to every subscriber of the
+// shared Broadcaster (SSE, WebSocket, and any other attached transport).
+func (c *HTTPController) Broadcast(eventType string, status PlayerStatus) {
+	start := time.Now()
+	c.broadcaster.Publish(eventType, status)
+	c.metrics.observeBroadcastLatency(time.Since(start))
+}
+
+// healthzHandler reports whether the player's goroutine is alive and
+// whether it's logged in, so orchestrators can restart stuck instances.
+func (c *HTTPController) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	alive := c.playerController.IsAlive()
+	status := c.playerController.GetStatus()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !alive {
+		w.WriteHeader(http.StatusServiceUnavailable)
 	}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"alive":     alive,
+		"logged_in": status.IsLoggedIn,
+	})
 }
 
+// readyzHandler reports whether the controller is ready to serve player
+// commands, i.e. logged in to a music service.
+func (c *HTTPController) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	status := c.playerController.GetStatus()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !status.IsLoggedIn {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"ready": status.IsLoggedIn})
+}
+
+// sseHandler streams typed status events (event: name, id: N, data: JSON).
+// A client that reconnects with a Last-Event-ID header is caught up on
+// every buffered event it missed before the stream resumes live. A
+// ?events=song_change,lyric query filters which event types are sent,
+// letting lightweight clients ignore the full status blob.
 func (c *HTTPController) sseHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 
-	clientChan := make(chan string, 1) // Buffered channel
-	c.mu.Lock()
-	c.sseClients[clientChan] = struct{}{}
-	c.mu.Unlock()
-
-	defer func() {
-		c.mu.Lock()
-		delete(c.sseClients, clientChan)
-		c.mu.Unlock()
-		close(clientChan)
-	}()
+	filter := parseEventFilter(r.URL.Query().Get("events"))
 
 	flusher, ok := w.(http.Flusher)
 	if !ok {
@@ -108,16 +228,37 @@ func (c *HTTPController) sseHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Send initial state immediately
-	initialStatus := c.playerController.GetStatus()
-	initialData, _ := json.Marshal(initialStatus)
-	fmt.Fprintf(w, "data: %s\n\n", initialData)
+	// Subscribe before replaying buffered events so nothing published in
+	// between is lost.
+	sub := c.broadcaster.Subscribe()
+	defer c.broadcaster.Unsubscribe(sub)
+
+	// Highest event ID already sent by the replay; live events up to it
+	// are skipped so none is sent twice.
+	var replayedID uint64
+	if lastID, ok := parseLastEventID(r); ok {
+		for _, event := range c.broadcaster.EventsSince(lastID) {
+			replayedID = event.ID
+			if !filter.allows(event.Type) {
+				continue
+			}
+			writeSSEEvent(w, event)
+		}
+	} else {
+		// No Last-Event-ID: send the current snapshot as a synthetic
+		// "status" event so new clients have something to render
+		// immediately.
+		writeSSEEvent(w, Event{ID: 0, Type: EventStatus, Status: c.playerController.GetStatus()})
+	}
 	flusher.Flush()
 
 	for {
 		select {
-		case data := <-clientChan:
-			fmt.Fprintf(w, "data: %s\n\n", data)
+		case event := <-sub:
+			if event.ID <= replayedID || !filter.allows(event.Type) {
+				continue
+			}
+			writeSSEEvent(w, event)
 			flusher.Flush()
 		case <-r.Context().Done():
 			return
@@ -125,6 +266,55 @@ func (c *HTTPController) sseHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// eventFilter restricts which event types an SSE subscriber receives. A
+// nil/empty filter allows everything.
+type eventFilter map[string]struct{}
+
+func parseEventFilter(raw string) eventFilter {
+	if raw == "" {
+		return nil
+	}
+	filter := make(eventFilter)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			filter[name] = struct{}{}
+		}
+	}
+	return filter
+}
+
+func (f eventFilter) allows(eventType string) bool {
+	if len(f) == 0 {
+		return true
+	}
+	_, ok := f[eventType]
+	return ok
+}
+
+// parseLastEventID reads the Last-Event-ID header, as sent automatically
+// by EventSource on reconnect.
+func parseLastEventID(r *http.Request) (uint64, bool) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+func writeSSEEvent(w http.ResponseWriter, event Event) {
+	data, err := json.Marshal(event.Status)
+	if err != nil {
+		slog.Error("Failed to marshal status for SSE broadcast", slog.Any("err", err))
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
+
 func (c *HTTPController) statusHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -150,6 +340,8 @@ type command struct {
 }
 
 func (c *HTTPController) commandHandler(w http.ResponseWriter, r *http.Request) {
+	c.auth.applyCORS(w, r)
+
 	if r.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
@@ -163,12 +355,51 @@ func (c *HTTPController) commandHandler(w http.ResponseWriter, r *http.Request)
 	}
 	defer r.Body.Close()
 
+	if !c.auth.authorize(r, scopeForCommand(cmd.Command)) {
+		w.Header().Set("WWW-Authenticate", `Bearer`)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"status": "error", "message": "unauthorized"}`))
+		return
+	}
+
+	message, status := dispatchCommand(c.playerController, cmd)
+	c.metrics.recordCommand(cmd.Command, status)
+	w.WriteHeader(status)
+	if status != http.StatusOK {
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"status": "error", "message": "%s"}`, message)))
+		return
+	}
+	_, _ = w.Write([]byte(`{"status": "ok"}`))
+}
+
+// scopeForCommand returns the scope required to run a given command name.
+// set_play_mode is gated separately from the rest of playback control so a
+// token can be granted one without the other.
+func scopeForCommand(name string) Scope {
+	if name == "set_play_mode" {
+		return ScopeModeWrite
+	}
+	return ScopePlayerControl
+}
+
+// arg returns cmd.Args[i], or "" if cmd has no such argument.
+func arg(cmd command, i int) string {
+	if i >= len(cmd.Args) {
+		return ""
+	}
+	return cmd.Args[i]
+}
+
+// dispatchCommand runs a decoded command against playerController and
+// reports an error message (empty on success) alongside the HTTP status
+// code that best describes the outcome. It is shared by every transport
+// (HTTP, WebSocket, gRPC, ...) so command handling behaves identically
+// regardless of how the client connected.
+func dispatchCommand(playerController PlayerController, cmd command) (message string, status int) {
 	switch cmd.Command {
 	case "set_play_mode":
 		if len(cmd.Args) != 1 {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"status": "error", "message": "invalid args"}`)) 
-			return
+			return "invalid args", http.StatusBadRequest
 		}
 		var mode types.Mode
 		switch cmd.Args[0] {
@@ -183,49 +414,119 @@ func (c *HTTPController) commandHandler(w http.ResponseWriter, r *http.Request)
 		case "inf_random":
 			mode = types.PmInfRandom
 		default:
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte(`{"status": "error", "message": "invalid play mode"}`)) 
-			return
+			return "invalid play mode", http.StatusBadRequest
 		}
-		if err := c.playerController.SetPlayMode(mode); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(fmt.Sprintf(`{"status": "error", "message": "%s"}`, err.Error())))
-			return
+		if err := playerController.SetPlayMode(mode); err != nil {
+			return err.Error(), http.StatusInternalServerError
 		}
-		c.playerController.Rerender() // Trigger UI refresh
-
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status": "ok"}`)) 
+		playerController.Rerender() // Trigger UI refresh
+		return "", http.StatusOK
 	case "play":
-		c.playerController.Play()
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status": "ok"}`)) 
+		playerController.Play()
+		return "", http.StatusOK
 	case "pause":
-		c.playerController.Pause()
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status": "ok"}`)) 
+		playerController.Pause()
+		return "", http.StatusOK
 	case "next":
-		c.playerController.Next()
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status": "ok"}`)) 
+		playerController.Next()
+		return "", http.StatusOK
 	case "previous":
-		c.playerController.Previous()
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status": "ok"}`)) 
+		playerController.Previous()
+		return "", http.StatusOK
 	case "next_play_mode":
-		c.playerController.NextPlayMode()
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status": "ok"}`)) 
+		playerController.NextPlayMode()
+		return "", http.StatusOK
 	case "activate_intelligent_mode":
-		if err := c.playerController.ActivateIntelligentMode(); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(fmt.Sprintf(`{"status": "error", "message": "%s"}`, err.Error())))
-			return
+		if err := playerController.ActivateIntelligentMode(); err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
+	case "seek":
+		raw := arg(cmd, 0)
+		if raw == "" {
+			return "invalid args", http.StatusBadRequest
+		}
+		relative := raw[0] == '+' || raw[0] == '-'
+		ms, err := strconv.Atoi(raw)
+		if err != nil {
+			return "invalid seek position", http.StatusBadRequest
+		}
+		if relative {
+			err = playerController.SeekRelative(time.Duration(ms) * time.Millisecond)
+		} else {
+			err = playerController.Seek(time.Duration(ms) * time.Millisecond)
+		}
+		if err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
+	case "set_volume":
+		volume, err := strconv.Atoi(arg(cmd, 0))
+		if err != nil || volume < 0 || volume > 100 {
+			return "invalid volume", http.StatusBadRequest
+		}
+		if err := playerController.SetVolume(volume); err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
+	case "enqueue":
+		if arg(cmd, 0) == "" {
+			return "invalid args", http.StatusBadRequest
 		}
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status": "ok"}`)) 
+		if err := playerController.Enqueue(arg(cmd, 0)); err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
+	case "dequeue":
+		index, err := strconv.Atoi(arg(cmd, 0))
+		if err != nil {
+			return "invalid args", http.StatusBadRequest
+		}
+		if err := playerController.Dequeue(index); err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
+	case "clear_queue":
+		playerController.ClearQueue()
+		return "", http.StatusOK
+	case "move_in_queue":
+		from, err1 := strconv.Atoi(arg(cmd, 0))
+		to, err2 := strconv.Atoi(arg(cmd, 1))
+		if err1 != nil || err2 != nil {
+			return "invalid args", http.StatusBadRequest
+		}
+		if err := playerController.MoveInQueue(from, to); err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
+	case "play_index":
+		index, err := strconv.Atoi(arg(cmd, 0))
+		if err != nil {
+			return "invalid args", http.StatusBadRequest
+		}
+		if err := playerController.PlayIndex(index); err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
+	case "like_song":
+		if err := playerController.LikeSong(arg(cmd, 0)); err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
+	case "unlike_song":
+		if err := playerController.UnlikeSong(arg(cmd, 0)); err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
+	case "add_to_playlist":
+		if arg(cmd, 0) == "" {
+			return "invalid args", http.StatusBadRequest
+		}
+		if err := playerController.AddToPlaylist(arg(cmd, 0), arg(cmd, 1)); err != nil {
+			return err.Error(), http.StatusInternalServerError
+		}
+		return "", http.StatusOK
 	default:
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte(`{"status": "error", "message": "unknown command"}`)) 
+		return "unknown command", http.StatusBadRequest
 	}
 }
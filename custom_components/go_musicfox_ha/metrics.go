@@ -0,0 +1,71 @@
+package remote_control
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// controllerMetrics holds the Prometheus collectors exposed on /metrics.
+// It owns its own registry rather than using prometheus's global default
+// so multiple HTTPControllers (e.g. in tests) don't collide on collector
+// registration.
+type controllerMetrics struct {
+	registry         *prometheus.Registry
+	commandsTotal    *prometheus.CounterVec
+	broadcastLatency prometheus.Histogram
+}
+
+// newControllerMetrics registers every collector, including GaugeFuncs that
+// read live state from broadcaster and playerController.
+func newControllerMetrics(playerController PlayerController, broadcaster *Broadcaster) *controllerMetrics {
+	m := &controllerMetrics{
+		registry: prometheus.NewRegistry(),
+		commandsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "musicfox_remote_commands_total",
+			Help: "Commands received by the remote control API, labeled by command name and outcome.",
+		}, []string{"command", "outcome"}),
+		broadcastLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name: "musicfox_remote_broadcast_latency_seconds",
+			Help: "Time taken to fan a status update out to every subscriber.",
+		}),
+	}
+
+	sseClients := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "musicfox_remote_subscribers",
+		Help: "Number of clients currently subscribed to status updates, across all transports.",
+	}, func() float64 { return float64(broadcaster.SubscriberCount()) })
+
+	uptime := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "musicfox_player_uptime_seconds",
+		Help: "Time since the player started.",
+	}, func() float64 { return playerController.Uptime().Seconds() })
+
+	songsPlayed := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "musicfox_player_songs_played_total",
+		Help: "Number of songs played this session.",
+	}, func() float64 { return float64(playerController.SongsPlayed()) })
+
+	currentSongDuration := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "musicfox_player_current_song_duration_seconds",
+		Help: "Duration of the currently playing song.",
+	}, func() float64 { return playerController.GetStatus().SongDuration.Seconds() })
+
+	m.registry.MustRegister(m.commandsTotal, m.broadcastLatency, sseClients, uptime, songsPlayed, currentSongDuration)
+	return m
+}
+
+// recordCommand increments the command counter for name, labeled ok or
+// error depending on status.
+func (m *controllerMetrics) recordCommand(name string, status int) {
+	outcome := "ok"
+	if status != 200 {
+		outcome = "error"
+	}
+	m.commandsTotal.WithLabelValues(name, outcome).Inc()
+}
+
+// observeBroadcastLatency records how long a single Broadcast call took.
+func (m *controllerMetrics) observeBroadcastLatency(d time.Duration) {
+	m.broadcastLatency.Observe(d.Seconds())
+}
@@ -0,0 +1,164 @@
+package remote_control
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+)
+
+// UnixSocketController is a Controller implementation for local IPC over a
+// Unix domain socket. It speaks the same newline-delimited JSON command
+// protocol as the HTTP controller, which suits local integrations such as
+// bitfocus companion modules that prefer a socket file over a TCP port.
+//
+// Unlike the HTTP and gRPC controllers, UnixSocketController does not check
+// AuthConfig: its trust boundary is the filesystem, not a credential. Run
+// creates the socket file with 0600 permissions so only the owning user (or
+// root) can connect, which is the access control a local-IPC transport is
+// expected to rely on.
+type UnixSocketController struct {
+	playerController  PlayerController
+	broadcaster       *Broadcaster
+	socketPath        string
+	listener          net.Listener
+	shutdownRequested bool
+	closed            chan struct{}
+	mu                sync.Mutex
+}
+
+// NewUnixSocketController creates a UnixSocketController listening on
+// socketPath.
+func NewUnixSocketController(playerController PlayerController, broadcaster *Broadcaster, socketPath string) *UnixSocketController {
+	return &UnixSocketController{
+		playerController: playerController,
+		broadcaster:      broadcaster,
+		socketPath:       socketPath,
+		closed:           make(chan struct{}),
+	}
+}
+
+// Run starts accepting connections on the Unix socket and blocks until it
+// is shut down. If Shutdown is called before Run has finished binding the
+// socket, Run notices and exits without accepting connections, rather
+// than leaving an orphaned listener running after the caller believes
+// shutdown completed.
+func (c *UnixSocketController) Run() error {
+	_ = os.Remove(c.socketPath)
+
+	lis, err := net.Listen("unix", c.socketPath)
+	if err != nil {
+		return fmt.Errorf("unix socket controller listen failed: %w", err)
+	}
+
+	c.mu.Lock()
+	if c.shutdownRequested {
+		c.mu.Unlock()
+		_ = lis.Close()
+		return nil
+	}
+	c.listener = lis
+	c.mu.Unlock()
+
+	if err := os.Chmod(c.socketPath, 0600); err != nil {
+		return fmt.Errorf("unix socket controller chmod failed: %w", err)
+	}
+
+	slog.Info("Unix socket controller is running on", slog.String("path", c.socketPath))
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			c.mu.Lock()
+			closing := c.listener == nil
+			c.mu.Unlock()
+			if closing {
+				// Shutdown closed the listener; exit quietly.
+				return nil
+			}
+			slog.Error("unix socket controller accept failed", slog.Any("err", err))
+			return err
+		}
+		go c.handleConn(conn)
+	}
+}
+
+// Shutdown stops accepting new connections, closes existing client
+// connections, and removes the socket file.
+func (c *UnixSocketController) Shutdown(_ context.Context) error {
+	c.mu.Lock()
+	if !c.shutdownRequested {
+		close(c.closed)
+	}
+	c.shutdownRequested = true
+	lis := c.listener
+	c.listener = nil
+	c.mu.Unlock()
+
+	if lis == nil {
+		return nil
+	}
+	if err := lis.Close(); err != nil {
+		return err
+	}
+	return os.Remove(c.socketPath)
+}
+
+// Broadcast publishes a typed status event to every subscriber of the
+// shared Broadcaster.
+func (c *UnixSocketController) Broadcast(eventType string, status PlayerStatus) {
+	c.broadcaster.Publish(eventType, status)
+}
+
+// handleConn serves one client connection: every line the client sends is
+// decoded as a command, and every published status update is written back
+// as a line of JSON until the client disconnects.
+func (c *UnixSocketController) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	sub := c.broadcaster.Subscribe()
+	defer c.broadcaster.Unsubscribe(sub)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		decoder := json.NewDecoder(conn)
+		for {
+			var cmd command
+			if err := decoder.Decode(&cmd); err != nil {
+				return
+			}
+			message, status := dispatchCommand(c.playerController, cmd)
+			resp := map[string]any{"status": "ok"}
+			if status != 200 {
+				resp = map[string]any{"status": "error", "message": message}
+			}
+			data, _ := json.Marshal(resp)
+			if _, err := conn.Write(append(data, '\n')); err != nil {
+				return
+			}
+		}
+	}()
+
+	encoder := json.NewEncoder(conn)
+	initial := c.playerController.GetStatus()
+	_ = encoder.Encode(initial)
+
+	for {
+		select {
+		case event, ok := <-sub:
+			if !ok {
+				return
+			}
+			if err := encoder.Encode(event.Status); err != nil {
+				return
+			}
+		case <-done:
+			return
+		case <-c.closed:
+			return
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package remote_control
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestUnixSocketHandleConnConcurrentWritesDoNotRace drives handleConn's
+// status-push writes (via Broadcaster.Publish) and its command-reply
+// writes (via client command frames) at the same time, against the same
+// connection, with -race enabled.
+func TestUnixSocketHandleConnConcurrentWritesDoNotRace(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "musicfox.sock")
+
+	broadcaster := NewBroadcaster()
+	player := &fakePlayerController{}
+	c := NewUnixSocketController(player, broadcaster, socketPath)
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- c.Run() }()
+
+	var conn net.Conn
+	var err error
+	for i := 0; i < 100; i++ {
+		conn, err = net.Dial("unix", socketPath)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	const rounds = 200
+	var wg sync.WaitGroup
+
+	done := make(chan struct{})
+	go func() {
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+		}
+		close(done)
+	}()
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			broadcaster.Publish(EventStatus, PlayerStatus{QueueIndex: i})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		encoder := json.NewEncoder(conn)
+		for i := 0; i < rounds; i++ {
+			if err := encoder.Encode(command{Command: "play"}); err != nil {
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	<-runErr
+	<-done
+}
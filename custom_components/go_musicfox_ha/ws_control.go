@@ -0,0 +1,150 @@
+package remote_control
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = (wsPongWait * 9) / 10
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// wsConn wraps a *websocket.Conn so every write goes through one mutex.
+// gorilla/websocket permits at most one concurrent writer per connection,
+// but wsHandler's write loop (broadcast events, pings) and read loop
+// (command replies) both write to the same connection from separate
+// goroutines; without this, concurrent writes race and can panic.
+type wsConn struct {
+	*websocket.Conn
+	mu sync.Mutex
+}
+
+func (c *wsConn) writeJSON(v any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_ = c.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return c.WriteJSON(v)
+}
+
+func (c *wsConn) writePing() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_ = c.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return c.WriteMessage(websocket.PingMessage, nil)
+}
+
+// wsMessage is the envelope for every frame exchanged over the WebSocket
+// channel. Type discriminates between a command submitted by the client
+// and a status push originating from the server.
+type wsMessage struct {
+	Type    string        `json:"type"`
+	Command *command      `json:"command,omitempty"`
+	Status  *PlayerStatus `json:"status,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// wsHandler upgrades the connection to a WebSocket and multiplexes status
+// pushes and command submissions over it.
+func (c *HTTPController) wsHandler(w http.ResponseWriter, r *http.Request) {
+	upgrader := wsUpgrader
+	upgrader.CheckOrigin = c.auth.checkOrigin
+	raw, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Error("ws controller upgrade failed", slog.Any("err", err))
+		return
+	}
+	conn := &wsConn{Conn: raw}
+
+	sub := c.broadcaster.Subscribe()
+
+	done := make(chan struct{})
+	go c.wsWriteLoop(conn, sub, done)
+	c.wsReadLoop(conn, r, done)
+
+	c.broadcaster.Unsubscribe(sub)
+}
+
+// wsWriteLoop forwards broadcast events to the client, using each event's
+// type as the message discriminator, and sends periodic pings to keep the
+// connection alive.
+func (c *HTTPController) wsWriteLoop(conn *wsConn, sub chan Event, done chan struct{}) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	initial := c.playerController.GetStatus()
+	_ = conn.writeJSON(wsMessage{Type: EventStatus, Status: &initial})
+
+	for {
+		select {
+		case event, ok := <-sub:
+			if !ok {
+				return
+			}
+			if err := conn.writeJSON(wsMessage{Type: event.Type, Status: &event.Status}); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.writePing(); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// wsReadLoop reads command frames from the client and dispatches them
+// through the same handling logic as the HTTP command endpoint. r is the
+// request that established the connection, used to re-check the scope a
+// command requires since a connection authorized to read status need not
+// also be authorized to control playback.
+func (c *HTTPController) wsReadLoop(conn *wsConn, r *http.Request, done chan struct{}) {
+	defer close(done)
+	defer conn.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				slog.Error("ws controller read failed", slog.Any("err", err))
+			}
+			return
+		}
+
+		if msg.Type != "command" || msg.Command == nil {
+			continue
+		}
+
+		resp := wsMessage{Type: "result"}
+		if !c.auth.authorize(r, scopeForCommand(msg.Command.Command)) {
+			resp.Error = "unauthorized"
+			c.metrics.recordCommand(msg.Command.Command, http.StatusUnauthorized)
+		} else {
+			result, status := dispatchCommand(c.playerController, *msg.Command)
+			c.metrics.recordCommand(msg.Command.Command, status)
+			if status != http.StatusOK {
+				resp.Error = result
+			}
+		}
+
+		if err := conn.writeJSON(resp); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package remote_control
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-musicfox/go-musicfox/internal/remote_control/discovery"
+	"github.com/gorilla/websocket"
+)
+
+// TestWsHandlerConcurrentWritesDoNotRace drives wsHandler's write loop
+// (via Broadcaster.Publish) and its read loop (via client command frames)
+// at the same time, against the same connection. Run with -race: before
+// wsConn's shared write mutex, this reproduced a race (and, under load, a
+// runtime panic) on gorilla/websocket's internal write state.
+func TestWsHandlerConcurrentWritesDoNotRace(t *testing.T) {
+	broadcaster := NewBroadcaster()
+	player := &fakePlayerController{}
+	c := NewHTTPController(player, broadcaster, 0, AuthConfig{}, discovery.Config{})
+
+	server := httptest.NewServer(http.HandlerFunc(c.wsHandler))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	const rounds = 200
+	var wg sync.WaitGroup
+
+	// Drain whatever the server writes (status pushes and command replies
+	// interleaved) so neither side blocks on a full buffer.
+	done := make(chan struct{})
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(done)
+				return
+			}
+		}
+	}()
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			broadcaster.Publish(EventStatus, PlayerStatus{QueueIndex: i})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			msg := wsMessage{Type: "command", Command: &command{Command: "play"}}
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	<-done
+}